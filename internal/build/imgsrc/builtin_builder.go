@@ -35,7 +35,7 @@ func (*builtinBuilder) Run(ctx context.Context, dockerFactory *dockerClientFacto
 		return nil, note, nil
 	}
 
-	builtin, err := builtins.GetBuiltin(opts.BuiltIn)
+	builtin, err := builtins.FindBuiltin(opts.BuiltIn, opts.WorkingDir)
 	if err != nil {
 		build.BuildFinish()
 		return nil, "", err
@@ -1,6 +1,7 @@
 package builtins
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"text/template"
@@ -21,6 +22,8 @@ type Builtin struct {
 	Template    string
 	Settings    []Setting
 	settingsMap map[string]Setting
+	// settingTypes holds declared types of settings for user provided builtins
+	settingTypes map[string]string
 }
 
 // GetBuiltin - Finds the Builtin by name
@@ -64,6 +67,44 @@ func (b *Builtin) ResolveSettings(vars map[string]interface{}) map[string]interf
 	return resolvedSettings
 }
 
+// Validate - Checks the builtin has a parseable template and uniquely named settings
+func (b *Builtin) Validate() error {
+	if b.Template == "" {
+		return errors.New("builtin has no template")
+	}
+
+	if _, err := template.New("builtin").Parse(b.Template); err != nil {
+		return fmt.Errorf("template: %w", err)
+	}
+
+	seen := make(map[string]bool, len(b.Settings))
+	for _, setting := range b.Settings {
+		if setting.Name == "" {
+			return errors.New("setting without a name")
+		}
+		if seen[setting.Name] {
+			return fmt.Errorf("setting %q defined more than once", setting.Name)
+		}
+		seen[setting.Name] = true
+	}
+
+	return nil
+}
+
+// ValidateSettings - Checks values of settings with a declared type
+func (b *Builtin) ValidateSettings(vars map[string]interface{}) error {
+	for k, v := range vars {
+		settingType, found := b.settingTypes[k]
+		if !found {
+			continue
+		}
+		if _, err := convertSettingValue(settingType, v); err != nil {
+			return fmt.Errorf("setting %q: %w", k, err)
+		}
+	}
+	return nil
+}
+
 // GetVDockerfile - given an map of variables, get the definition and populate it
 func (b *Builtin) GetVDockerfile(vars map[string]interface{}) (string, error) {
 	template, err := template.New("builtin").Parse(b.Template)
@@ -71,6 +112,10 @@ func (b *Builtin) GetVDockerfile(vars map[string]interface{}) (string, error) {
 		return "", err
 	}
 
+	if err := b.ValidateSettings(vars); err != nil {
+		return "", err
+	}
+
 	// Now the create the proper vars from
 	// If it's set in the vars map, set it in the settings map
 
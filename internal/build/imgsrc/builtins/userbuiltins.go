@@ -0,0 +1,226 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/superfly/flyctl/helpers"
+)
+
+// userSetting is the on-disk form of a Setting in a user provided builtin
+type userSetting struct {
+	Name        string      `toml:"name"`
+	Type        string      `toml:"type"`
+	Default     interface{} `toml:"default"`
+	Description string      `toml:"description"`
+}
+
+// userBuiltin is the on-disk form of a user provided builtin
+type userBuiltin struct {
+	Name        string        `toml:"name"`
+	Description string        `toml:"description"`
+	Details     string        `toml:"details"`
+	Template    string        `toml:"template"`
+	Settings    []userSetting `toml:"settings"`
+}
+
+// Supported setting types for user provided builtins
+const (
+	SettingTypeString = "string"
+	SettingTypeBool   = "bool"
+	SettingTypeInt    = "int"
+	SettingTypeArray  = "array"
+)
+
+// FindBuiltin - Finds a Builtin by name or path. Values ending in .toml or
+// containing a path separator are loaded as user provided builtins, relative
+// to workingDir. Other names are looked up in the compiled in builtins and
+// then in the builtins directory of the flyctl config directory.
+func FindBuiltin(name string, workingDir string) (*Builtin, error) {
+	if isBuiltinPath(name) {
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(workingDir, path)
+		}
+		return LoadBuiltin(path)
+	}
+
+	if builtin, err := GetBuiltin(name); err == nil {
+		return builtin, nil
+	}
+
+	dir, err := UserBuiltinsDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, name+".toml")
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("no builtin with %s name supported", name)
+	}
+
+	return LoadBuiltin(path)
+}
+
+// UserBuiltinsDirectory - Directory searched for user provided builtins
+func UserBuiltinsDirectory() (string, error) {
+	dir, err := helpers.GetConfigDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "builtins"), nil
+}
+
+// GetUserBuiltins - Get all the user provided builtins in the builtins directory
+func GetUserBuiltins() ([]Builtin, error) {
+	dir, err := UserBuiltinsDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.toml"))
+	if err != nil {
+		return nil, err
+	}
+
+	userbuiltins := make([]Builtin, 0, len(paths))
+	for _, path := range paths {
+		builtin, err := LoadBuiltin(path)
+		if err != nil {
+			return nil, err
+		}
+		userbuiltins = append(userbuiltins, *builtin)
+	}
+
+	return userbuiltins, nil
+}
+
+// LoadBuiltin - Loads and validates a user provided builtin from a TOML file
+func LoadBuiltin(path string) (*Builtin, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read builtin %s: %w", path, err)
+	}
+
+	builtin, err := ParseBuiltin(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid builtin %s: %w", path, err)
+	}
+
+	if builtin.Name == "" {
+		builtin.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return builtin, nil
+}
+
+// ParseBuiltin - Parses and validates a user provided builtin definition
+func ParseBuiltin(data []byte) (*Builtin, error) {
+	var ub userBuiltin
+	if err := toml.Unmarshal(data, &ub); err != nil {
+		return nil, err
+	}
+
+	builtin := &Builtin{
+		Name:         ub.Name,
+		Description:  ub.Description,
+		Details:      ub.Details,
+		Template:     ub.Template,
+		settingTypes: map[string]string{},
+	}
+
+	for _, us := range ub.Settings {
+		settingType := us.Type
+		if settingType == "" {
+			settingType = typeOfValue(us.Default)
+		}
+
+		def, err := convertSettingValue(settingType, us.Default)
+		if err != nil {
+			return nil, fmt.Errorf("setting %q: default: %w", us.Name, err)
+		}
+
+		builtin.Settings = append(builtin.Settings, Setting{
+			Name:        us.Name,
+			Default:     def,
+			Description: us.Description,
+		})
+		builtin.settingTypes[us.Name] = settingType
+	}
+
+	if err := builtin.Validate(); err != nil {
+		return nil, err
+	}
+
+	return builtin, nil
+}
+
+func isBuiltinPath(name string) bool {
+	return strings.HasSuffix(name, ".toml") || strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator)
+}
+
+func typeOfValue(v interface{}) string {
+	switch v.(type) {
+	case bool:
+		return SettingTypeBool
+	case int, int64:
+		return SettingTypeInt
+	case []interface{}, []string:
+		return SettingTypeArray
+	default:
+		return SettingTypeString
+	}
+}
+
+// convertSettingValue checks v against settingType and converts it to the
+// representation used in templates
+func convertSettingValue(settingType string, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	switch settingType {
+	case SettingTypeString:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case SettingTypeBool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case SettingTypeInt:
+		switch n := v.(type) {
+		case int:
+			return int64(n), nil
+		case int64:
+			return n, nil
+		case float64:
+			if n == float64(int64(n)) {
+				return int64(n), nil
+			}
+		}
+	case SettingTypeArray:
+		switch a := v.(type) {
+		case []string:
+			return a, nil
+		case []interface{}:
+			strs := make([]string, 0, len(a))
+			for _, e := range a {
+				s, ok := e.(string)
+				if !ok {
+					return nil, errors.New("expected an array of strings")
+				}
+				strs = append(strs, s)
+			}
+			return strs, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %q", settingType)
+	}
+
+	return nil, fmt.Errorf("expected a value of type %s, got %v", settingType, v)
+}
@@ -0,0 +1,101 @@
+package builtins
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const houseNode = `
+description = "House Node image"
+template = """
+FROM registry.example.com/node:{{.version}}
+{{if .production}}ENV NODE_ENV=production{{end}}
+EXPOSE {{.port}}
+CMD [{{range .cmd}}"{{.}}",{{end}}]
+"""
+
+[[settings]]
+name = "version"
+default = "20"
+description = "Node version"
+
+[[settings]]
+name = "production"
+type = "bool"
+default = true
+
+[[settings]]
+name = "port"
+default = 8080
+
+[[settings]]
+name = "cmd"
+default = ["npm", "start"]
+`
+
+func TestBasicBuiltinsValidate(t *testing.T) {
+	for _, builtin := range GetBuiltins() {
+		assert.NoError(t, builtin.Validate(), builtin.Name)
+	}
+}
+
+func TestParseBuiltin(t *testing.T) {
+	builtin, err := ParseBuiltin([]byte(houseNode))
+	require.NoError(t, err)
+
+	dockerfile, err := builtin.GetVDockerfile(map[string]interface{}{"version": "22", "port": int64(3000)})
+	require.NoError(t, err)
+	assert.Contains(t, dockerfile, "FROM registry.example.com/node:22")
+	assert.Contains(t, dockerfile, "ENV NODE_ENV=production")
+	assert.Contains(t, dockerfile, "EXPOSE 3000")
+	assert.Contains(t, dockerfile, `CMD ["npm","start",]`)
+
+	_, err = builtin.GetVDockerfile(map[string]interface{}{"production": "yes"})
+	assert.ErrorContains(t, err, `setting "production"`)
+}
+
+func TestParseBuiltinInvalid(t *testing.T) {
+	_, err := ParseBuiltin([]byte(`template = "FROM {{.version"`))
+	assert.ErrorContains(t, err, "template")
+
+	_, err = ParseBuiltin([]byte("template = \"FROM scratch\"\n[[settings]]\nname = \"a\"\n[[settings]]\nname = \"a\"\n"))
+	assert.ErrorContains(t, err, "more than once")
+
+	_, err = ParseBuiltin([]byte("template = \"FROM scratch\"\n[[settings]]\nname = \"a\"\ntype = \"int\"\ndefault = \"x\"\n"))
+	assert.ErrorContains(t, err, "expected a value of type int")
+}
+
+func TestFindBuiltin(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("FLY_CONFIG_DIR", configDir)
+
+	workingDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDir, "builders"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "builders", "house.toml"), []byte(houseNode), 0o644))
+
+	builtin, err := FindBuiltin("./builders/house.toml", workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, "house", builtin.Name)
+
+	builtin, err = FindBuiltin("node", workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, "node", builtin.Name)
+
+	_, err = FindBuiltin("house-node", workingDir)
+	assert.Error(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(configDir, "builtins"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "builtins", "house-node.toml"), []byte(houseNode), 0o644))
+
+	builtin, err = FindBuiltin("house-node", workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, "House Node image", builtin.Description)
+
+	userbuiltins, err := GetUserBuiltins()
+	require.NoError(t, err)
+	assert.Len(t, userbuiltins, 1)
+}